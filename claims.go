@@ -1,15 +1,51 @@
 package jwtauth
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Claims is a collection of claims extracted from a JWT.
 type Claims map[string]interface{}
 
+// Has returns true if the named claim is present, regardless of its value.
+func (c Claims) Has(name string) bool {
+	_, ok := c[name]
+	return ok
+}
+
+// Decode unmarshals c into v, a pointer to a user-defined struct, by
+// round-tripping through JSON. Field tags follow the usual encoding/json
+// rules, so applications can define strongly-typed claim structs instead of
+// going through String/Strings/Int for every claim:
+//
+//	type MyClaims struct {
+//		Roles    []string `json:"roles"`
+//		TenantID string   `json:"tid"`
+//	}
+//	var mc MyClaims
+//	err := claims.Decode(&mc)
+func (c Claims) Decode(v interface{}) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// MustDecode is like Decode but panics if decoding fails. It is intended for
+// use with claim structs that are known to match the token shape, e.g. right
+// after validating the token against a fixed schema.
+func (c Claims) MustDecode(v interface{}) {
+	if err := c.Decode(v); err != nil {
+		panic(err)
+	}
+}
+
 // stringify transforms your world into a magical place filled with elves and
 // unicorns.
 func stringify(value interface{}) string {
@@ -124,33 +160,53 @@ func (c Claims) Int(name string) int64 {
 }
 
 // Time returns the named claim as a Time in the Unix epoch. If the claim
-// is absent or cannot be converted to an integer, it returns 0.
+// is absent or cannot be converted to a time, it returns the Unix epoch
+// (time.Unix(0, 0) in UTC).
 func (c Claims) Time(name string) time.Time {
+	t, _ := c.TimeOk(name)
+	return t
+}
+
+// timeFromNumericDate converts an RFC 7519 NumericDate value (seconds since
+// the Unix epoch, fractional seconds allowed) into a Time.
+func timeFromNumericDate(seconds float64) time.Time {
+	whole := int64(seconds)
+	frac := seconds - float64(whole)
+	return time.Unix(whole, int64(frac*float64(time.Second))).UTC()
+}
+
+// TimeOk returns the named claim as a Time in the Unix epoch, along with a
+// boolean indicating whether the claim was present and convertible. This
+// lets callers distinguish an absent claim from one that legitimately
+// encodes the Unix epoch (time zero).
+func (c Claims) TimeOk(name string) (time.Time, bool) {
 	switch ts := c[name].(type) {
 	case uint64:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case uint32:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case uint:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case int64:
-		return time.Unix(ts, 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case int32:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case int:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case float64:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(ts), true
 	case float32:
-		return time.Unix(int64(ts), 0).UTC()
+		return timeFromNumericDate(float64(ts)), true
 	case string:
-		t, err := time.Parse(time.RFC822, ts)
-		if err == nil {
-			return t.UTC()
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			return t.UTC(), true
 		}
-		return time.Unix(0, 0).UTC()
+		if t, err := time.Parse(time.RFC822, ts); err == nil {
+			return t.UTC(), true
+		}
+		return time.Unix(0, 0).UTC(), false
 	default:
-		return time.Unix(0, 0).UTC()
+		return time.Unix(0, 0).UTC(), false
 	}
 }
 
@@ -180,3 +236,33 @@ func (c Claims) NotBefore() time.Time {
 func (c Claims) ExpiresAt() time.Time {
 	return c.Time("exp")
 }
+
+// Scopes returns the standard "scope" claim as a list of scopes. The usual
+// RFC 6749/RFC 8693 representation is a single space-delimited string, which
+// Scopes splits on whitespace; a claim that already decoded as a list (some
+// providers emit a JSON array instead) is returned as-is via Strings. If the
+// claim is absent, Scopes returns nil.
+func (c Claims) Scopes() []string {
+	switch v := c["scope"].(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		return strings.Fields(v)
+	default:
+		return c.Strings("scope")
+	}
+}
+
+// HasScope returns true if the standard "scope" claim contains scope as one
+// of its space-delimited values.
+func (c Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package jwtauth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClaimsTimeOk(t *testing.T) {
+	cases := []struct {
+		name    string
+		claims  Claims
+		wantOk  bool
+		wantSec int64
+		wantNS  int64
+	}{
+		{"absent", Claims{}, false, 0, 0},
+		{"int64 numeric date", Claims{"exp": int64(1700000000)}, true, 1700000000, 0},
+		{"float64 numeric date with fraction", Claims{"exp": float64(1700000000.5)}, true, 1700000000, 5e8},
+		{"rfc3339 string", Claims{"exp": "2023-11-14T22:13:20Z"}, true, 1700000000, 0},
+		{"rfc822 string", Claims{"exp": "14 Nov 23 22:13 UTC"}, true, 1699999980, 0},
+		{"unparseable string", Claims{"exp": "not a time"}, false, 0, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.claims.TimeOk("exp")
+			if ok != tc.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tc.wantOk)
+			}
+			if !tc.wantOk {
+				return
+			}
+			if got.Unix() != tc.wantSec || int64(got.Nanosecond()) != tc.wantNS {
+				t.Fatalf("got %v (unix=%d ns=%d), want unix=%d ns=%d", got, got.Unix(), got.Nanosecond(), tc.wantSec, tc.wantNS)
+			}
+		})
+	}
+}
+
+func TestClaimsTimeAbsentIsEpoch(t *testing.T) {
+	got := Claims{}.Time("exp")
+	if !got.Equal(time.Unix(0, 0).UTC()) {
+		t.Fatalf("Time on absent claim = %v, want Unix epoch", got)
+	}
+}
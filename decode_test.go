@@ -0,0 +1,64 @@
+package jwtauth
+
+import "testing"
+
+func TestClaimsDecode(t *testing.T) {
+	claims := Claims{
+		"roles": []interface{}{"admin", "user"},
+		"tid":   "tenant-1",
+		"sub":   "abc123",
+	}
+
+	type myClaims struct {
+		Roles    []string `json:"roles"`
+		TenantID string   `json:"tid"`
+	}
+
+	var mc myClaims
+	if err := claims.Decode(&mc); err != nil {
+		t.Fatalf("Decode = %v, want nil", err)
+	}
+	if mc.TenantID != "tenant-1" {
+		t.Fatalf("TenantID = %q, want %q", mc.TenantID, "tenant-1")
+	}
+	if len(mc.Roles) != 2 || mc.Roles[0] != "admin" || mc.Roles[1] != "user" {
+		t.Fatalf("Roles = %v, want [admin user]", mc.Roles)
+	}
+}
+
+func TestClaimsDecodeTypeMismatch(t *testing.T) {
+	claims := Claims{"tid": "not-a-number"}
+
+	type myClaims struct {
+		TenantID int `json:"tid"`
+	}
+
+	var mc myClaims
+	if err := claims.Decode(&mc); err == nil {
+		t.Fatalf("Decode = nil, want error decoding a string claim into an int field")
+	}
+}
+
+func TestClaimsMustDecodePanicsOnError(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("MustDecode did not panic on decode error")
+		}
+	}()
+
+	type myClaims struct {
+		TenantID int `json:"tid"`
+	}
+	Claims{"tid": "not-a-number"}.MustDecode(&myClaims{})
+}
+
+func TestClaimsHas(t *testing.T) {
+	claims := Claims{"sub": "abc123"}
+
+	if !claims.Has("sub") {
+		t.Fatalf("Has(%q) = false, want true", "sub")
+	}
+	if claims.Has("missing") {
+		t.Fatalf("Has(%q) = true, want false", "missing")
+	}
+}
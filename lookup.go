@@ -0,0 +1,154 @@
+package jwtauth
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Lookup navigates c using path, a slash-separated RFC 6901 JSON Pointer
+// (e.g. "/realm_access/roles/0") or, equivalently, a dotted path
+// ("realm_access.roles.0"). Each segment is used as a map key against
+// map[string]interface{} values or parsed as an index against
+// []interface{} values. Per RFC 6901, "~1" and "~0" within a segment
+// unescape to "/" and "~" respectively.
+//
+// Lookup returns false if path is empty, or if any segment is missing or
+// does not match the type of value it is applied to.
+//
+// This is primarily useful for identity providers (Keycloak, Auth0,
+// Cognito, ...) that nest authorization data inside custom namespaced
+// claims, e.g. realm_access.roles.
+func (c Claims) Lookup(path string) (interface{}, bool) {
+	segments := splitPath(path)
+	if len(segments) == 0 {
+		return nil, false
+	}
+
+	var cur interface{} = map[string]interface{}(c)
+	for _, seg := range segments {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case Claims:
+			next, ok := v[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []interface{}:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			cur = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// splitPath splits a JSON Pointer ("/a/b/0") or dotted path ("a.b.0") into
+// its unescaped segments. A leading "/" is treated as the RFC 6901 root and
+// ignored.
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	sep := "."
+	if strings.HasPrefix(path, "/") {
+		sep = "/"
+		path = path[1:]
+	}
+	if path == "" {
+		return nil
+	}
+
+	raw := strings.Split(path, sep)
+	segments := make([]string, len(raw))
+	for i, s := range raw {
+		segments[i] = unescapePointerSegment(s)
+	}
+	return segments
+}
+
+// unescapePointerSegment reverses RFC 6901 "~1" -> "/" and "~0" -> "~"
+// escaping. The order matters: "~1" must be unescaped before "~0" would
+// otherwise mangle a literal "~01" sequence.
+func unescapePointerSegment(s string) string {
+	if !strings.Contains(s, "~") {
+		return s
+	}
+	s = strings.ReplaceAll(s, "~1", "/")
+	s = strings.ReplaceAll(s, "~0", "~")
+	return s
+}
+
+// LookupString is like Lookup but converts the result to a string using the
+// same rules as String. It returns "", false if path cannot be resolved.
+func (c Claims) LookupString(path string) (string, bool) {
+	v, ok := c.Lookup(path)
+	if !ok {
+		return "", false
+	}
+	return stringify(v), true
+}
+
+// LookupStrings is like Lookup but converts the result to a []string using
+// the same rules as Strings. It returns nil, false if path cannot be
+// resolved.
+func (c Claims) LookupStrings(path string) ([]string, bool) {
+	v, ok := c.Lookup(path)
+	if !ok {
+		return nil, false
+	}
+	switch tv := v.(type) {
+	case []string:
+		return tv, true
+	case string:
+		return []string{tv}, true
+	case []interface{}:
+		slice := make([]string, len(tv))
+		for i, e := range tv {
+			slice[i] = stringify(e)
+		}
+		return slice, true
+	default:
+		return []string{stringify(tv)}, true
+	}
+}
+
+// LookupInt is like Lookup but converts the result to an int64 using the
+// same rules as Int. It returns 0, false if path cannot be resolved or the
+// value cannot be converted.
+func (c Claims) LookupInt(path string) (int64, bool) {
+	v, ok := c.Lookup(path)
+	if !ok {
+		return 0, false
+	}
+	switch tv := v.(type) {
+	case uint64, uint32, uint, int64, int32, int, float64, float32:
+	case string:
+		if _, err := strconv.ParseInt(tv, 10, 64); err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return Claims{"v": v}.Int("v"), true
+}
+
+// LookupBool is like Lookup but converts the result to a bool using the
+// same rules as Bool. It returns false, false if path cannot be resolved.
+func (c Claims) LookupBool(path string) (bool, bool) {
+	v, ok := c.Lookup(path)
+	if !ok {
+		return false, false
+	}
+	return Claims{"v": v}.Bool("v"), true
+}
@@ -0,0 +1,67 @@
+package jwtauth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClaimsLookup(t *testing.T) {
+	claims := Claims{
+		"realm_access": map[string]interface{}{
+			"roles": []interface{}{"admin", "user"},
+		},
+		"https://example.com/roles": []interface{}{"a", "b"},
+		"sub":                       "abc123",
+	}
+
+	cases := []struct {
+		name string
+		path string
+		want interface{}
+		ok   bool
+	}{
+		{"dotted path into nested object", "realm_access.roles", []interface{}{"admin", "user"}, true},
+		{"dotted path with array index", "realm_access.roles.0", "admin", true},
+		{"json pointer with array index", "/realm_access/roles/0", "admin", true},
+		{"json pointer escaped segment", "/https:~1~1example.com~1roles/1", "b", true},
+		{"top level scalar", "sub", "abc123", true},
+		{"missing top level", "nope", nil, false},
+		{"missing nested segment", "realm_access.missing", nil, false},
+		{"index out of range", "realm_access.roles.5", nil, false},
+		{"index into non-array", "sub.0", nil, false},
+		{"empty path", "", nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := claims.Lookup(tc.path)
+			if ok != tc.ok {
+				t.Fatalf("ok = %v, want %v", ok, tc.ok)
+			}
+			if tc.ok && !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClaimsLookupStrings(t *testing.T) {
+	claims := Claims{
+		"realm_access": map[string]interface{}{
+			"roles": []interface{}{"admin", "user"},
+		},
+	}
+
+	got, ok := claims.LookupStrings("realm_access.roles")
+	if !ok {
+		t.Fatalf("ok = false, want true")
+	}
+	want := []string{"admin", "user"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, ok := claims.LookupStrings("realm_access.missing"); ok {
+		t.Fatalf("ok = true for missing path, want false")
+	}
+}
@@ -0,0 +1,180 @@
+package jwtauth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrTokenIssuedInFuture is returned by the rule built by IssuedAtRule when
+// the claims' "iat" claim is after now (beyond the configured leeway).
+var ErrTokenIssuedInFuture = errors.New("jwtauth: token issued in the future")
+
+// ErrMissingScope is returned by the rule built by RequireScopes when the
+// claims' "scope" claim does not satisfy the configured match mode.
+var ErrMissingScope = errors.New("jwtauth: missing required scope")
+
+// ErrMissingRole is returned by the rule built by RequireRoles when the
+// claims' role claim does not satisfy the configured match mode.
+var ErrMissingRole = errors.New("jwtauth: missing required role")
+
+// Rule is a single unit of claims-based authorization. It is handed the
+// validated Claims, the inbound request so it can, for example, take the
+// requested route into account, and now so time-based rules can be tested
+// against a fixed clock instead of time.Now. It returns a non-nil error to
+// reject the request.
+type Rule func(c Claims, req *http.Request, now time.Time) error
+
+// MatchMode controls whether RequireScopes/RequireRoles require all of the
+// configured values to be present (MatchAll) or just one of them
+// (MatchAny).
+type MatchMode int
+
+const (
+	// MatchAny is satisfied if at least one of the configured values is
+	// present.
+	MatchAny MatchMode = iota
+	// MatchAll is satisfied only if every configured value is present.
+	MatchAll
+)
+
+// Validator authorizes claims by running them through an ordered list of
+// Rules, stopping at and returning the first error. It gives applications a
+// composable alternative to writing per-endpoint claim inspection code
+// around the bare Claims map.
+type Validator struct {
+	rules []Rule
+}
+
+// NewValidator returns a Validator that runs rules in order.
+func NewValidator(rules ...Rule) *Validator {
+	return &Validator{rules: rules}
+}
+
+// Validate runs c and req through the validator's rules in order, passing
+// now to each time-based rule, and returns the first error encountered, or
+// nil if every rule passes.
+func (v *Validator) Validate(c Claims, req *http.Request, now time.Time) error {
+	for _, rule := range v.rules {
+		if err := rule(c, req, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimsValidatorRule returns a Rule that delegates to cv, letting a
+// ClaimsValidator (exp/nbf leeway plus iss/aud allow-lists) be composed into
+// a Validator chain alongside the scope/role rules below.
+func ClaimsValidatorRule(cv *ClaimsValidator) Rule {
+	return func(c Claims, _ *http.Request, now time.Time) error {
+		return cv.Validate(c, now)
+	}
+}
+
+// ExpNbfRule returns a Rule that validates the "exp" and "nbf" claims,
+// allowing expLeeway and nbfLeeway of clock skew respectively. It is a
+// convenience wrapper around ClaimsValidatorRule for the common case where
+// no iss/aud allow-list is needed.
+func ExpNbfRule(expLeeway, nbfLeeway time.Duration) Rule {
+	return ClaimsValidatorRule(&ClaimsValidator{ExpLeeway: expLeeway, NbfLeeway: nbfLeeway})
+}
+
+// IssuerRule returns a Rule that rejects claims whose "iss" is not one of
+// issuers. Unlike ExpNbfRule, it does not go through ClaimsValidatorRule:
+// ClaimsValidator.Validate always checks exp/nbf too, which would re-run
+// that check with this rule's (zero) leeway and clobber an earlier
+// ExpNbfRule's leeway when the two are chained in the same Validator.
+func IssuerRule(issuers ...string) Rule {
+	cv := &ClaimsValidator{Issuers: issuers}
+	return func(c Claims, _ *http.Request, _ time.Time) error {
+		return cv.validateIssAud(c)
+	}
+}
+
+// AudienceRule returns a Rule that rejects claims whose "aud" does not
+// contain at least one of audiences. See IssuerRule for why this doesn't go
+// through ClaimsValidatorRule.
+func AudienceRule(audiences ...string) Rule {
+	cv := &ClaimsValidator{Audiences: audiences}
+	return func(c Claims, _ *http.Request, _ time.Time) error {
+		return cv.validateIssAud(c)
+	}
+}
+
+// IssuedAtRule returns a Rule that rejects claims whose "iat" is after now,
+// allowing leeway of clock skew. Claims without an "iat" claim pass.
+func IssuedAtRule(leeway time.Duration) Rule {
+	return func(c Claims, _ *http.Request, now time.Time) error {
+		iat, ok := c.TimeOk("iat")
+		if !ok {
+			return nil
+		}
+		if now.Before(iat.Add(-leeway)) {
+			return ErrTokenIssuedInFuture
+		}
+		return nil
+	}
+}
+
+// RequireScopes returns a Rule that checks the standard "scope" claim
+// (Claims.Scopes) against scopes according to mode: MatchAny requires at
+// least one of scopes to be present, MatchAll requires all of them. With no
+// scopes configured, the rule always passes.
+func RequireScopes(mode MatchMode, scopes ...string) Rule {
+	return func(c Claims, _ *http.Request, _ time.Time) error {
+		if matchClaim(c.Scopes(), scopes, mode) {
+			return nil
+		}
+		return ErrMissingScope
+	}
+}
+
+// RequireRoles returns a Rule that checks the named claim (e.g. "roles", or
+// a namespaced claim resolved via Claims.LookupStrings such as
+// "realm_access.roles") against roles according to mode: MatchAny requires
+// at least one of roles to be present, MatchAll requires all of them. With
+// no roles configured, the rule always passes, regardless of whether the
+// named claim is present, matching RequireScopes.
+func RequireRoles(claimName string, mode MatchMode, roles ...string) Rule {
+	return func(c Claims, _ *http.Request, _ time.Time) error {
+		if len(roles) == 0 {
+			return nil
+		}
+		have, ok := c.LookupStrings(claimName)
+		if !ok {
+			return ErrMissingRole
+		}
+		if matchClaim(have, roles, mode) {
+			return nil
+		}
+		return ErrMissingRole
+	}
+}
+
+// matchClaim reports whether have satisfies want according to mode.
+func matchClaim(have, want []string, mode MatchMode) bool {
+	if len(want) == 0 {
+		return true
+	}
+	haveSet := make(map[string]bool, len(have))
+	for _, h := range have {
+		haveSet[h] = true
+	}
+	switch mode {
+	case MatchAll:
+		for _, w := range want {
+			if !haveSet[w] {
+				return false
+			}
+		}
+		return true
+	default: // MatchAny
+		for _, w := range want {
+			if haveSet[w] {
+				return true
+			}
+		}
+		return false
+	}
+}
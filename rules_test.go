@@ -0,0 +1,136 @@
+package jwtauth
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIssuerRuleDoesNotClobberExpLeeway guards against IssuerRule/AudienceRule
+// silently re-checking exp/nbf with zero leeway when chained after an
+// ExpNbfRule that was configured with a non-zero leeway.
+func TestIssuerRuleDoesNotClobberExpLeeway(t *testing.T) {
+	now := time.Now()
+	c := Claims{
+		"exp": now.Add(-2 * time.Minute).Unix(),
+		"iss": "https://issuer.example.com",
+	}
+
+	v := NewValidator(
+		ExpNbfRule(5*time.Minute, 0),
+		IssuerRule("https://issuer.example.com"),
+	)
+
+	if err := v.Validate(c, nil, now); err != nil {
+		t.Fatalf("Validate = %v, want nil (exp within ExpNbfRule's leeway)", err)
+	}
+}
+
+func TestRequireRolesEmptyRolesIsNoOp(t *testing.T) {
+	rule := RequireRoles("roles", MatchAll)
+	if err := rule(Claims{}, nil, time.Time{}); err != nil {
+		t.Fatalf("RequireRoles with no roles configured = %v, want nil even when claim is absent", err)
+	}
+}
+
+func TestAudienceRule(t *testing.T) {
+	rule := AudienceRule("https://api.example.com")
+
+	match := Claims{"aud": "https://api.example.com"}
+	if err := rule(match, nil, time.Time{}); err != nil {
+		t.Fatalf("Validate on matching aud = %v, want nil", err)
+	}
+
+	mismatch := Claims{"aud": "https://other.example.com"}
+	if err := rule(mismatch, nil, time.Time{}); err != ErrInvalidAudience {
+		t.Fatalf("Validate on non-matching aud = %v, want ErrInvalidAudience", err)
+	}
+}
+
+func TestIssuedAtRule(t *testing.T) {
+	now := time.Now()
+	rule := IssuedAtRule(time.Minute)
+
+	future := Claims{"iat": now.Add(5 * time.Minute).Unix()}
+	if err := rule(future, nil, now); err != ErrTokenIssuedInFuture {
+		t.Fatalf("Validate on future iat = %v, want ErrTokenIssuedInFuture", err)
+	}
+
+	withinLeeway := Claims{"iat": now.Add(30 * time.Second).Unix()}
+	if err := rule(withinLeeway, nil, now); err != nil {
+		t.Fatalf("Validate on iat within leeway = %v, want nil", err)
+	}
+
+	past := Claims{"iat": now.Add(-time.Hour).Unix()}
+	if err := rule(past, nil, now); err != nil {
+		t.Fatalf("Validate on past iat = %v, want nil", err)
+	}
+}
+
+func TestRequireScopesMatchModes(t *testing.T) {
+	claims := Claims{"scope": "read write"}
+
+	anyRule := RequireScopes(MatchAny, "write", "admin")
+	if err := anyRule(claims, nil, time.Time{}); err != nil {
+		t.Fatalf("MatchAny with one matching scope = %v, want nil", err)
+	}
+
+	allRule := RequireScopes(MatchAll, "read", "write")
+	if err := allRule(claims, nil, time.Time{}); err != nil {
+		t.Fatalf("MatchAll with all scopes present = %v, want nil", err)
+	}
+
+	missingRule := RequireScopes(MatchAll, "read", "admin")
+	if err := missingRule(claims, nil, time.Time{}); err != ErrMissingScope {
+		t.Fatalf("MatchAll with a missing scope = %v, want ErrMissingScope", err)
+	}
+
+	noneRule := RequireScopes(MatchAny, "admin")
+	if err := noneRule(claims, nil, time.Time{}); err != ErrMissingScope {
+		t.Fatalf("MatchAny with no matching scope = %v, want ErrMissingScope", err)
+	}
+}
+
+func TestClaimsScopesAndHasScope(t *testing.T) {
+	claims := Claims{"scope": "read write admin"}
+
+	want := []string{"read", "write", "admin"}
+	got := claims.Scopes()
+	if len(got) != len(want) {
+		t.Fatalf("Scopes() = %v, want %v", got, want)
+	}
+	for i, s := range want {
+		if got[i] != s {
+			t.Fatalf("Scopes() = %v, want %v", got, want)
+		}
+	}
+
+	if !claims.HasScope("write") {
+		t.Fatalf("HasScope(%q) = false, want true", "write")
+	}
+	if claims.HasScope("delete") {
+		t.Fatalf("HasScope(%q) = true, want false", "delete")
+	}
+
+	if got := (Claims{}).Scopes(); got != nil {
+		t.Fatalf("Scopes() on absent claim = %v, want nil", got)
+	}
+}
+
+func TestClaimsScopesArrayForm(t *testing.T) {
+	claims := Claims{"scope": []interface{}{"read", "write"}}
+
+	want := []string{"read", "write"}
+	got := claims.Scopes()
+	if len(got) != len(want) {
+		t.Fatalf("Scopes() = %v, want %v", got, want)
+	}
+	for i, s := range want {
+		if got[i] != s {
+			t.Fatalf("Scopes() = %v, want %v", got, want)
+		}
+	}
+
+	if !claims.HasScope("write") {
+		t.Fatalf("HasScope(%q) = false, want true", "write")
+	}
+}
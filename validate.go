@@ -0,0 +1,77 @@
+package jwtauth
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTokenExpired is returned by Validate when the claims' "exp" claim is in
+// the past (beyond the configured leeway).
+var ErrTokenExpired = errors.New("jwtauth: token is expired")
+
+// ErrTokenNotYetValid is returned by Validate when the claims' "nbf" claim is
+// in the future (beyond the configured leeway).
+var ErrTokenNotYetValid = errors.New("jwtauth: token is not yet valid")
+
+// ErrInvalidIssuer is returned by ClaimsValidator.Validate when the claims'
+// "iss" claim is not in the configured allow-list.
+var ErrInvalidIssuer = errors.New("jwtauth: invalid issuer")
+
+// ErrInvalidAudience is returned by ClaimsValidator.Validate when the claims'
+// "aud" claim does not match any of the configured allowed audiences.
+var ErrInvalidAudience = errors.New("jwtauth: invalid audience")
+
+// Validate checks the "exp" and "nbf" claims against now, allowing expLeeway
+// and nbfLeeway of clock skew respectively. An absent or unparseable exp or
+// nbf claim is not considered a failure, while one that encodes the Unix
+// epoch is checked like any other time: callers that require the claim to be
+// present should check for it separately.
+func (c Claims) Validate(now time.Time, expLeeway, nbfLeeway time.Duration) error {
+	if exp, ok := c.TimeOk("exp"); ok && now.After(exp.Add(expLeeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := c.TimeOk("nbf"); ok && now.Before(nbf.Add(-nbfLeeway)) {
+		return ErrTokenNotYetValid
+	}
+	return nil
+}
+
+// ClaimsValidator validates claims against a set of static rules: clock-skew
+// leeway for "exp"/"nbf" plus optional "iss"/"aud" allow-lists. It is the
+// simplest way to apply RFC 7519 validation consistently across a service;
+// middleware should use it instead of comparing timestamps by hand.
+type ClaimsValidator struct {
+	// ExpLeeway is the clock-skew tolerance applied to the "exp" claim.
+	ExpLeeway time.Duration
+	// NbfLeeway is the clock-skew tolerance applied to the "nbf" claim.
+	NbfLeeway time.Duration
+	// Issuers, if non-empty, restricts accepted "iss" claims to this list.
+	Issuers []string
+	// Audiences, if non-empty, requires the "aud" claim to contain at least
+	// one of these values.
+	Audiences []string
+}
+
+// Validate runs c through the validator's clock-skew and allow-list checks,
+// returning the first failure encountered.
+func (v *ClaimsValidator) Validate(c Claims, now time.Time) error {
+	if err := c.Validate(now, v.ExpLeeway, v.NbfLeeway); err != nil {
+		return err
+	}
+	return v.validateIssAud(c)
+}
+
+// validateIssAud runs just the iss/aud allow-list checks, without the
+// exp/nbf clock-skew check in Validate. It exists so Rule constructors that
+// only care about iss/aud (IssuerRule, AudienceRule) don't implicitly
+// re-run exp/nbf with this ClaimsValidator's (zero) leeway when chained
+// after an ExpNbfRule configured with its own leeway.
+func (v *ClaimsValidator) validateIssAud(c Claims) error {
+	if len(v.Issuers) > 0 && !matchClaim([]string{c.Issuer()}, v.Issuers, MatchAny) {
+		return ErrInvalidIssuer
+	}
+	if len(v.Audiences) > 0 && !matchClaim(c.Strings("aud"), v.Audiences, MatchAny) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
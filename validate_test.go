@@ -0,0 +1,38 @@
+package jwtauth
+
+import (
+	"testing"
+	"time"
+)
+
+// TestClaimsValidateAbsentClaimsPass guards the doc comment's claim that an
+// absent "exp"/"nbf" is not a validation failure. Claims.Time represents
+// absence and the Unix epoch identically, so Validate must use TimeOk (not
+// a zero-value check on Time's return) to tell them apart.
+func TestClaimsValidateAbsentClaimsPass(t *testing.T) {
+	if err := (Claims{}).Validate(time.Now(), 0, 0); err != nil {
+		t.Fatalf("Validate on claims with no exp/nbf = %v, want nil", err)
+	}
+}
+
+func TestClaimsValidateExpired(t *testing.T) {
+	now := time.Now()
+	c := Claims{"exp": now.Add(-time.Hour).Unix()}
+	if err := c.Validate(now, 0, 0); err != ErrTokenExpired {
+		t.Fatalf("Validate = %v, want ErrTokenExpired", err)
+	}
+	if err := c.Validate(now, 2*time.Hour, 0); err != nil {
+		t.Fatalf("Validate with sufficient leeway = %v, want nil", err)
+	}
+}
+
+func TestClaimsValidateNotYetValid(t *testing.T) {
+	now := time.Now()
+	c := Claims{"nbf": now.Add(time.Hour).Unix()}
+	if err := c.Validate(now, 0, 0); err != ErrTokenNotYetValid {
+		t.Fatalf("Validate = %v, want ErrTokenNotYetValid", err)
+	}
+	if err := c.Validate(now, 0, 2*time.Hour); err != nil {
+		t.Fatalf("Validate with sufficient leeway = %v, want nil", err)
+	}
+}